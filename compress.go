@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdConfig collects the options a caller can set through NewZstdWriter /
+// NewZstdReader. Fields that don't apply to the requested direction (e.g. a
+// decoder dictionary passed to NewZstdWriter) are simply ignored; the
+// concurrency setting is used by both.
+type zstdConfig struct {
+	level        zstd.EncoderLevel
+	concurrency  int
+	encoderDict  []byte
+	decoderDicts [][]byte
+}
+
+// ZstdOption configures a streaming zstd writer or reader.
+type ZstdOption func(*zstdConfig)
+
+// WithLevel sets the encoder's compression/speed tradeoff, e.g.
+// zstd.SpeedFastest .. zstd.SpeedBestCompression.
+func WithLevel(level zstd.EncoderLevel) ZstdOption {
+	return func(c *zstdConfig) { c.level = level }
+}
+
+// WithEncoderConcurrency sets how many goroutines the encoder may use.
+// NewZstdReader applies the same count to the decoder.
+func WithEncoderConcurrency(n int) ZstdOption {
+	return func(c *zstdConfig) { c.concurrency = n }
+}
+
+// WithEncoderDict sets a precomputed dictionary the encoder compresses
+// against. Use LoadZstdDict to read one from disk.
+func WithEncoderDict(dict []byte) ZstdOption {
+	return func(c *zstdConfig) { c.encoderDict = dict }
+}
+
+// WithDecoderDicts registers precomputed dictionaries the decoder may need,
+// matched automatically by the dictionary ID embedded in the stream. Use
+// LoadZstdDict to read them from disk.
+func WithDecoderDicts(dicts ...[]byte) ZstdOption {
+	return func(c *zstdConfig) { c.decoderDicts = dicts }
+}
+
+// LoadZstdDict reads a precomputed zstd dictionary from disk for use with
+// WithEncoderDict / WithDecoderDicts.
+func LoadZstdDict(path string) ([]byte, error) {
+	dict, err := os.ReadFile(path)
+	if err != nil {
+		return nil, WrapErrf(err, "reading zstd dict %q", path)
+	}
+	return dict, nil
+}
+
+// NewZstdWriter wraps w in a streaming zstd encoder. Callers must Close it
+// to flush the final frame.
+func NewZstdWriter(w io.Writer, opts ...ZstdOption) (io.WriteCloser, error) {
+	cfg := &zstdConfig{level: zstd.SpeedDefault}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	eopts := []zstd.EOption{zstd.WithEncoderLevel(cfg.level)}
+	if cfg.concurrency > 0 {
+		eopts = append(eopts, zstd.WithEncoderConcurrency(cfg.concurrency))
+	}
+	if cfg.encoderDict != nil {
+		eopts = append(eopts, zstd.WithEncoderDict(cfg.encoderDict))
+	}
+
+	enc, err := zstd.NewWriter(w, eopts...)
+	if err != nil {
+		return nil, WrapErr(err, "zstd.NewWriter failed")
+	}
+	return enc, nil
+}
+
+// NewZstdReader wraps r in a streaming zstd decoder. Callers must Close it
+// once done to release the decoder's goroutines.
+func NewZstdReader(r io.Reader, opts ...ZstdOption) (io.ReadCloser, error) {
+	cfg := &zstdConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	dopts := []zstd.DOption{}
+	if cfg.concurrency > 0 {
+		dopts = append(dopts, zstd.WithDecoderConcurrency(cfg.concurrency))
+	}
+	if len(cfg.decoderDicts) > 0 {
+		dopts = append(dopts, zstd.WithDecoderDicts(cfg.decoderDicts...))
+	}
+
+	dec, err := zstd.NewReader(r, dopts...)
+	if err != nil {
+		return nil, WrapErr(err, "zstd.NewReader failed")
+	}
+	return dec.IOReadCloser(), nil
+}
+
+// CompressBytes compresses in using the shared encoder pool, avoiding the
+// io.Copy overhead Compress pays for already-buffered data.
+func CompressBytes(in []byte) []byte {
+	e := zstdEncPool.Get()
+	enc := e.(*zstd.Encoder)
+	defer zstdEncPool.Put(enc)
+	return enc.EncodeAll(in, make([]byte, 0, len(in)))
+}
+
+// DecompressBytes decompresses in using the shared decoder pool, the
+// buffered counterpart to Decompress.
+func DecompressBytes(in []byte) ([]byte, error) {
+	d := zstdDecPool.Get()
+	dec := d.(*zstd.Decoder)
+	defer zstdDecPool.Put(dec)
+	out, err := dec.DecodeAll(in, nil)
+	if err != nil {
+		return nil, WrapErr(err, "DecodeAll failed")
+	}
+	return out, nil
+}
+
+// Codec is a streaming compression scheme that can be registered under a
+// name and used interchangeably with the zstd helpers above.
+type Codec interface {
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	codecRegistryMu sync.RWMutex
+	codecRegistry   = map[string]Codec{}
+)
+
+func init() {
+	RegisterCodec("zstd", zstdCodec{})
+}
+
+// zstdCodec adapts NewZstdWriter/NewZstdReader to the Codec interface using
+// default options.
+type zstdCodec struct{}
+
+func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return NewZstdWriter(w) }
+func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error)  { return NewZstdReader(r) }
+
+// RegisterCodec makes a Codec available under name for lookup via
+// CodecByName, so callers can swap in gzip/flate/lz4 behind the same
+// interface used by the zstd helpers.
+func RegisterCodec(name string, c Codec) {
+	codecRegistryMu.Lock()
+	defer codecRegistryMu.Unlock()
+	codecRegistry[name] = c
+}
+
+// CodecByName returns the Codec previously registered under name, if any.
+func CodecByName(name string) (Codec, bool) {
+	codecRegistryMu.RLock()
+	defer codecRegistryMu.RUnlock()
+	c, ok := codecRegistry[name]
+	return c, ok
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestCompressBytesRoundTrip(t *testing.T) {
+	in := []byte("the quick brown fox jumps over the lazy dog")
+	compressed := CompressBytes(in)
+	out, err := DecompressBytes(compressed)
+	if err != nil {
+		t.Fatalf("DecompressBytes returned error: %v", err)
+	}
+	if !bytes.Equal(in, out) {
+		t.Fatalf("round trip mismatch: got %q, want %q", out, in)
+	}
+}
+
+func TestNewZstdWriterReaderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewZstdWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewZstdWriter returned error: %v", err)
+	}
+	if _, err := w.Write([]byte("streamed payload")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	r, err := NewZstdReader(&buf)
+	if err != nil {
+		t.Fatalf("NewZstdReader returned error: %v", err)
+	}
+	defer r.Close()
+	out := make([]byte, len("streamed payload"))
+	if _, err := io.ReadFull(r, out); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if string(out) != "streamed payload" {
+		t.Fatalf("got %q, want %q", out, "streamed payload")
+	}
+}
+
+func TestRegisterCodecConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			RegisterCodec("zstd", zstdCodec{})
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			CodecByName("zstd")
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := CodecByName("zstd"); !ok {
+		t.Fatalf("CodecByName(zstd) not found after concurrent registration")
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// WrappedError is an error that carries the call frame it was created at
+// along with an optional message and underlying cause, so the chain can be
+// walked programmatically instead of being flattened into a string.
+type WrappedError struct {
+	frame runtime.Frame
+	msg   string
+	cause error
+}
+
+// callerFrame captures the single frame selected by `skip`, counted as
+// runtime.Callers counts it: 0 is runtime.Callers, 1 is callerFrame itself.
+func callerFrame(skip int) runtime.Frame {
+	pc := make([]uintptr, 1)
+	n := runtime.Callers(skip, pc)
+	if n == 0 {
+		return runtime.Frame{Function: "unknown"}
+	}
+	frame, _ := runtime.CallersFrames(pc[:n]).Next()
+	return frame
+}
+
+func newWrappedError(cause error, msg string) *WrappedError {
+	return &WrappedError{
+		frame: callerFrame(4),
+		msg:   msg,
+		cause: cause,
+	}
+}
+
+// WrapErr wraps err with a prefix of
+// Function Name -> [msgs[0] -> msgs[1] -> ... msgs[i]] -> err
+// The returned error keeps err as its cause, so errors.Is/errors.As still
+// see through the wrapping.
+func WrapErr(err error, msgs ...string) error {
+	return newWrappedError(err, strings.Join(msgs, " -> "))
+}
+
+// WrapErrf is like WrapErr but builds the message with fmt.Sprintf.
+func WrapErrf(err error, format string, args ...interface{}) error {
+	return newWrappedError(err, fmt.Sprintf(format, args...))
+}
+
+// NewErr creates a root error carrying no cause, capturing the caller's
+// frame the same way WrapErr does.
+func NewErr(msg string) error {
+	return newWrappedError(nil, msg)
+}
+
+// Error renders "Function Name -> msg -> cause", omitting any empty segment.
+func (e *WrappedError) Error() string {
+	parts := make([]string, 0, 3)
+	if e.frame.Function != "" {
+		parts = append(parts, e.frame.Function)
+	}
+	if e.msg != "" {
+		parts = append(parts, e.msg)
+	}
+	if e.cause != nil {
+		parts = append(parts, e.cause.Error())
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// Unwrap returns the underlying cause. errors.Is/errors.As already walk the
+// chain through this alone - they must not also get their own Is/As methods
+// here, or each layer doubles the work the other does.
+func (e *WrappedError) Unwrap() error {
+	return e.cause
+}
+
+// StackTrace walks err's wrapping chain and returns every frame captured by
+// WrapErr, WrapErrf or NewErr, outermost (most recent) call first.
+func StackTrace(err error) []runtime.Frame {
+	var frames []runtime.Frame
+	for err != nil {
+		if we, ok := err.(*WrappedError); ok {
+			frames = append(frames, we.frame)
+		}
+		err = errors.Unwrap(err)
+	}
+	return frames
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapErrIs(t *testing.T) {
+	sentinel := errors.New("boom")
+	wrapped := WrapErr(sentinel, "doing thing")
+
+	if !errors.Is(wrapped, sentinel) {
+		t.Fatalf("errors.Is(wrapped, sentinel) = false, want true")
+	}
+}
+
+type myErr struct{ msg string }
+
+func (e *myErr) Error() string { return e.msg }
+
+func TestWrapErrAs(t *testing.T) {
+	cause := &myErr{msg: "original"}
+	wrapped := WrapErr(cause, "context")
+
+	var got *myErr
+	if !errors.As(wrapped, &got) {
+		t.Fatalf("errors.As(wrapped, &got) = false, want true")
+	}
+	if got != cause {
+		t.Fatalf("errors.As resolved to %v, want %v", got, cause)
+	}
+}
+
+func TestWrapErrDeepChainIsFast(t *testing.T) {
+	root := errors.New("root")
+	var err error = root
+	for i := 0; i < 40; i++ {
+		err = WrapErr(err, "layer")
+	}
+	if !errors.Is(err, root) {
+		t.Fatalf("errors.Is(err, root) = false through 40 layers, want true")
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	err := WrapErr(WrapErr(errors.New("root"), "inner"), "outer")
+	frames := StackTrace(err)
+	if len(frames) != 2 {
+		t.Fatalf("StackTrace returned %d frames, want 2", len(frames))
+	}
+}
+
+func TestNewErr(t *testing.T) {
+	err := NewErr("something failed")
+	if err.Error() == "" {
+		t.Fatalf("NewErr produced empty message")
+	}
+}
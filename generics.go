@@ -0,0 +1,47 @@
+package utils
+
+// Number is any built-in integer or floating-point type, used to constrain
+// ToInt64 and ToFloat64.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Ptr returns a pointer to a copy of v, useful for taking the address of a
+// literal or a field that isn't addressable. Replaces the 14 `*Ptr`
+// functions in utils.go (StringPtr .. BoolPtr), which are now thin wrappers
+// over it.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Deref returns *p, or def if p is nil.
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// Coalesce returns the first non-zero value in vals, or the zero value of T
+// if they're all zero.
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// ToInt64 converts any Number to int64.
+func ToInt64[T Number](v T) int64 {
+	return int64(v)
+}
+
+// ToFloat64 converts any Number to float64.
+func ToFloat64[T Number](v T) float64 {
+	return float64(v)
+}
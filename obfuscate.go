@@ -0,0 +1,152 @@
+package utils
+
+import "strings"
+
+// ObfuscatePolicy selects which masking rule Obfuscate applies.
+type ObfuscatePolicy int
+
+const (
+	// AutoPolicy dispatches to Detect(s) to pick a policy.
+	AutoPolicy ObfuscatePolicy = iota
+	EmailPolicy
+	PhonePolicy
+	NamePolicy
+)
+
+// Detect classifies s as an email, phone number, or plain name so Obfuscate
+// can pick the right masking rule automatically.
+func Detect(s string) ObfuscatePolicy {
+	if strings.Contains(s, "@") {
+		return EmailPolicy
+	}
+	if looksLikePhone(s) {
+		return PhonePolicy
+	}
+	return NamePolicy
+}
+
+// looksLikePhone reports whether s is made up entirely of digits and common
+// phone punctuation (including '*', so an already-obfuscated phone number is
+// still recognized as one - required for Obfuscate to be idempotent).
+func looksLikePhone(s string) bool {
+	hasDigit := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r == '+' || r == '-' || r == '.' || r == '(' || r == ')' || r == ' ' || r == '*':
+			// allowed phone punctuation
+		default:
+			return false
+		}
+	}
+	return hasDigit
+}
+
+// Obfuscate masks s according to policy, detecting it automatically when
+// policy is AutoPolicy.
+func Obfuscate(s string, policy ObfuscatePolicy) string {
+	if policy == AutoPolicy {
+		policy = Detect(s)
+	}
+	switch policy {
+	case EmailPolicy:
+		return ObfuscateEmail(s)
+	case PhonePolicy:
+		return ObfuscatePhone(s)
+	default:
+		return ObfuscateName(s)
+	}
+}
+
+// ObfuscateEmail masks an email's local part, preserving its first and last
+// rune and the domain untouched, e.g. "j***e@example.com".
+func ObfuscateEmail(s string) string {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 {
+		return ObfuscateName(s)
+	}
+	return maskMiddle(s[:at]) + s[at:]
+}
+
+// ObfuscatePhone masks a phone number's digits, keeping a leading "+"
+// country code and the last 2-4 digits visible and leaving every separator
+// (spaces, dashes, parens) untouched, e.g. "+1 (555) 123-4567" becomes
+// "+1 (***) ***-4567".
+func ObfuscatePhone(s string) string {
+	runes := []rune(s)
+
+	var digitIdx []int
+	for i, r := range runes {
+		if r >= '0' && r <= '9' {
+			digitIdx = append(digitIdx, i)
+		}
+	}
+	total := len(digitIdx)
+	if total == 0 {
+		return s
+	}
+
+	keepLast := 4
+	if total < keepLast {
+		keepLast = total
+	}
+	countryLen := leadingDigitRun(runes)
+	if countryLen > 3 {
+		countryLen = 3
+	}
+	if countryLen > total-keepLast {
+		countryLen = total - keepLast
+	}
+
+	out := append([]rune(nil), runes...)
+	for pos, idx := range digitIdx {
+		if pos < countryLen || pos >= total-keepLast {
+			continue
+		}
+		out[idx] = '*'
+	}
+	return string(out)
+}
+
+// leadingDigitRun returns the length of the run of digits immediately
+// following a leading "+", i.e. the country code in "+1 (555) 123-4567".
+// Returns 0 if s doesn't start with "+".
+func leadingDigitRun(runes []rune) int {
+	if len(runes) == 0 || runes[0] != '+' {
+		return 0
+	}
+	n := 0
+	for i := 1; i < len(runes) && runes[i] >= '0' && runes[i] <= '9'; i++ {
+		n++
+	}
+	return n
+}
+
+// ObfuscateName masks every space-separated token of s, preserving each
+// token's first and last rune, e.g. "Jane Doe" -> "J**e D*e".
+func ObfuscateName(s string) string {
+	tokens := strings.Split(s, " ")
+	for i, t := range tokens {
+		tokens[i] = maskMiddle(t)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// maskMiddle replaces every rune of s except the first and last with '*',
+// operating on runes so multi-byte UTF-8 input isn't mangled.
+func maskMiddle(s string) string {
+	runes := []rune(s)
+	n := len(runes)
+	if n <= 2 {
+		return strings.Repeat("*", n)
+	}
+
+	masked := make([]rune, n)
+	masked[0] = runes[0]
+	masked[n-1] = runes[n-1]
+	for i := 1; i < n-1; i++ {
+		masked[i] = '*'
+	}
+	return string(masked)
+}
@@ -0,0 +1,66 @@
+package utils
+
+import "testing"
+
+func TestObfuscateEmailRuneSafe(t *testing.T) {
+	got := ObfuscateEmail("jösé@example.com")
+	if got[len(got)-len("@example.com"):] != "@example.com" {
+		t.Fatalf("domain not preserved: %q", got)
+	}
+}
+
+func TestObfuscatePhoneE164NotFullyLeaked(t *testing.T) {
+	got := ObfuscatePhone("+15551234567")
+	if got == "+15551234567" {
+		t.Fatalf("ObfuscatePhone left an E.164 number completely unmasked: %q", got)
+	}
+	if got[:4] != "+155" || got[len(got)-4:] != "4567" {
+		t.Fatalf("expected country code and last 4 digits preserved, got %q", got)
+	}
+}
+
+func TestObfuscatePhoneWithSeparators(t *testing.T) {
+	got := ObfuscatePhone("+1 (555) 123-4567")
+	want := "+1 (***) ***-4567"
+	if got != want {
+		t.Fatalf("ObfuscatePhone(%q) = %q, want %q", "+1 (555) 123-4567", got, want)
+	}
+}
+
+func TestObfuscatePhoneIdempotent(t *testing.T) {
+	for _, s := range []string{"+15551234567", "+1 (555) 123-4567", "+442079460958"} {
+		once := ObfuscatePhone(s)
+		twice := ObfuscatePhone(once)
+		if once != twice {
+			t.Fatalf("ObfuscatePhone not idempotent for %q: %q then %q", s, once, twice)
+		}
+	}
+}
+
+func TestObfuscateAutoPolicyIdempotent(t *testing.T) {
+	once := Obfuscate("+1 (555) 123-4567", AutoPolicy)
+	twice := Obfuscate(once, AutoPolicy)
+	if once != twice {
+		t.Fatalf("Obfuscate(AutoPolicy) not idempotent: %q then %q", once, twice)
+	}
+}
+
+func TestObfuscateNameTokens(t *testing.T) {
+	got := ObfuscateName("Jane Doe")
+	want := "J**e D*e"
+	if got != want {
+		t.Fatalf("ObfuscateName() = %q, want %q", got, want)
+	}
+}
+
+func TestDetectEmail(t *testing.T) {
+	if Detect("a@b.com") != EmailPolicy {
+		t.Fatalf("Detect did not classify email as EmailPolicy")
+	}
+}
+
+func TestDetectName(t *testing.T) {
+	if Detect("Jane Doe") != NamePolicy {
+		t.Fatalf("Detect did not classify name as NamePolicy")
+	}
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"html"
+	"reflect"
+	"strings"
+)
+
+// WalkStrings recursively descends into v via reflection, applying fn to
+// every reachable string and *string: nested structs, slices, arrays, maps
+// and embedded types are all followed. v must contain a pointer somewhere
+// in its chain (e.g. pass a struct pointer, or a pointer field) for fn's
+// results to be observable by the caller. Pointers are tracked in a
+// visited set so self-referential graphs don't recurse forever. A struct
+// field with a `sanitize` tag is handled by the tag instead of fn: "skip"
+// leaves it untouched, and any comma-separated combination of "html",
+// "trim" and "lower" applies those transforms in order.
+func WalkStrings(v interface{}, fn func(string) string) {
+	walkValue(reflect.ValueOf(v), fn, map[uintptr]bool{})
+}
+
+func walkValue(rv reflect.Value, fn func(string) string, visited map[uintptr]bool) {
+	if !rv.IsValid() {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return
+		}
+		ptr := rv.Pointer()
+		if visited[ptr] {
+			return
+		}
+		visited[ptr] = true
+		walkValue(rv.Elem(), fn, visited)
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			return
+		}
+		walkValue(rv.Elem(), fn, visited)
+
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if tag, ok := t.Field(i).Tag.Lookup("sanitize"); ok {
+				applyTag(field, tag, visited)
+				continue
+			}
+			walkValue(field, fn, visited)
+		}
+
+	case reflect.String:
+		if rv.CanSet() {
+			rv.SetString(fn(rv.String()))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			walkValue(rv.Index(i), fn, visited)
+		}
+
+	case reflect.Map:
+		if rv.IsNil() {
+			return
+		}
+		for _, key := range rv.MapKeys() {
+			val := rv.MapIndex(key)
+			elem := reflect.New(val.Type()).Elem()
+			elem.Set(val)
+			walkValue(elem, fn, visited)
+			rv.SetMapIndex(key, elem)
+		}
+	}
+}
+
+// applyTag overrides the walk's transform for a single field based on its
+// `sanitize` struct tag: "skip" leaves it untouched, "html" HTML-escapes
+// every string beneath it, and any comma-separated combination of "trim"
+// and "lower" applies those transforms in order.
+func applyTag(field reflect.Value, tag string, visited map[uintptr]bool) {
+	if tag == "skip" {
+		return
+	}
+
+	parts := strings.Split(tag, ",")
+	override := func(s string) string {
+		for _, p := range parts {
+			switch strings.TrimSpace(p) {
+			case "html":
+				s = html.EscapeString(s)
+			case "trim":
+				s = strings.TrimSpace(s)
+			case "lower":
+				s = strings.ToLower(s)
+			}
+		}
+		return s
+	}
+	walkValue(field, override, visited)
+}
+
+// XSSSanitizeStruct HTML-escapes every reachable string in s, including
+// nested structs, slices and maps. Fields tagged `sanitize:"skip"` are left
+// untouched; other `sanitize` tag values override the escaping for that
+// field, see WalkStrings. Given any struct `s` - accepts reflect.Value(&s).
+func XSSSanitizeStruct(v reflect.Value) {
+	if v.Kind() != reflect.Ptr {
+		return
+	}
+	WalkStrings(v.Interface(), html.EscapeString)
+}
+
+// TrimStruct trims every reachable string in s, including nested structs,
+// slices and maps. Fields tagged `sanitize:"skip"` are left untouched; other
+// `sanitize` tag values override the trimming for that field, see
+// WalkStrings. Given a struct `s` - accepts reflect.Value(&s).
+func TrimStruct(v reflect.Value) {
+	if v.Kind() != reflect.Ptr {
+		return
+	}
+	WalkStrings(v.Interface(), strings.TrimSpace)
+}
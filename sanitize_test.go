@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type inner struct {
+	Name string
+	Tags []string
+}
+
+type outer struct {
+	Title   string
+	Ptr     *string
+	Inner   inner
+	Inners  []inner
+	Meta    map[string]string
+	Skipped string `sanitize:"skip"`
+}
+
+func TestWalkStringsNested(t *testing.T) {
+	skip := "  keep me  "
+	o := &outer{
+		Title: "  hello  ",
+		Ptr:   strPtr("  world  "),
+		Inner: inner{
+			Name: "  nested  ",
+			Tags: []string{" a ", " b "},
+		},
+		Inners:  []inner{{Name: "  deep  "}},
+		Meta:    map[string]string{"k": "  v  "},
+		Skipped: skip,
+	}
+
+	WalkStrings(o, strings.TrimSpace)
+
+	if o.Title != "hello" {
+		t.Errorf("Title = %q, want %q", o.Title, "hello")
+	}
+	if *o.Ptr != "world" {
+		t.Errorf("Ptr = %q, want %q", *o.Ptr, "world")
+	}
+	if o.Inner.Name != "nested" {
+		t.Errorf("Inner.Name = %q, want %q", o.Inner.Name, "nested")
+	}
+	if o.Inner.Tags[0] != "a" || o.Inner.Tags[1] != "b" {
+		t.Errorf("Inner.Tags = %v, want [a b]", o.Inner.Tags)
+	}
+	if o.Inners[0].Name != "deep" {
+		t.Errorf("Inners[0].Name = %q, want %q", o.Inners[0].Name, "deep")
+	}
+	if o.Meta["k"] != "v" {
+		t.Errorf("Meta[k] = %q, want %q", o.Meta["k"], "v")
+	}
+	if o.Skipped != skip {
+		t.Errorf("Skipped = %q, want unchanged %q", o.Skipped, skip)
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+type selfRef struct {
+	Name string
+	Next *selfRef
+}
+
+func TestWalkStringsCycle(t *testing.T) {
+	a := &selfRef{Name: "  a  "}
+	a.Next = a // self-referential
+
+	WalkStrings(a, strings.TrimSpace) // must terminate, not stack-overflow
+
+	if a.Name != "a" {
+		t.Errorf("Name = %q, want %q", a.Name, "a")
+	}
+}
+
+func TestXSSSanitizeStruct(t *testing.T) {
+	type s struct {
+		Body string
+	}
+	v := &s{Body: "<script>alert(1)</script>"}
+	XSSSanitizeStruct(reflect.ValueOf(v))
+	if strings.Contains(v.Body, "<script>") {
+		t.Errorf("Body not escaped: %q", v.Body)
+	}
+}
+
+func TestTrimStruct(t *testing.T) {
+	type s struct {
+		Name string
+	}
+	v := &s{Name: "  padded  "}
+	TrimStruct(reflect.ValueOf(v))
+	if v.Name != "padded" {
+		t.Errorf("Name = %q, want %q", v.Name, "padded")
+	}
+}
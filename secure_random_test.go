@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecureRandomFromBytesLength(t *testing.T) {
+	s := SecureRandomString(16)
+	if len(s) != 16 {
+		t.Fatalf("len(s) = %d, want 16", len(s))
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("abcdefghijklmnopqrstuvwxyz", r) {
+			t.Fatalf("unexpected rune %q in %q", r, s)
+		}
+	}
+}
+
+func TestSecureRandomNumbers(t *testing.T) {
+	s := SecureRandomNumbers(10)
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			t.Fatalf("non-digit rune %q in %q", r, s)
+		}
+	}
+}
+
+func TestSecureRandomFromBytesOversizedAlphabetDoesNotHang(t *testing.T) {
+	letters := make([]byte, 257)
+	for i := range letters {
+		letters[i] = byte(i % 256)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer func() { recover(); close(done) }()
+		SecureRandomFromBytes(4, letters)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("SecureRandomFromBytes did not return with an alphabet >256 bytes")
+	}
+}
+
+func TestSecureRandomBytesLength(t *testing.T) {
+	b := SecureRandomBytes(32)
+	if len(b) != 32 {
+		t.Fatalf("len(b) = %d, want 32", len(b))
+	}
+}
+
+func TestUUIDv7Format(t *testing.T) {
+	u := UUIDv7()
+	parts := strings.Split(u, "-")
+	if len(parts) != 5 {
+		t.Fatalf("UUIDv7() = %q, want 5 dash-separated groups", u)
+	}
+	if parts[2][0] != '7' {
+		t.Fatalf("UUIDv7() version nibble = %q, want 7xxx", parts[2])
+	}
+	variant := parts[3][0]
+	if variant != '8' && variant != '9' && variant != 'a' && variant != 'b' {
+		t.Fatalf("UUIDv7() variant nibble = %q, want 8/9/a/b", string(variant))
+	}
+}
+
+func TestUUIDv7Ordered(t *testing.T) {
+	a := UUIDv7()
+	time.Sleep(2 * time.Millisecond)
+	b := UUIDv7()
+	if a >= b {
+		t.Fatalf("UUIDv7() not monotonic: %q then %q", a, b)
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	crand "crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ULIDGenerator produces ULIDs as described at "github.com/oklog/ulid/v2",
+// serializing access to a single monotonic entropy source behind a mutex so
+// IDs generated within the same millisecond still sort correctly. The
+// entropy is drawn from crypto/rand for each new millisecond and
+// incremented within one, rather than reseeding math/rand per ULID, which
+// keeps it both unpredictable and monotonic.
+type ULIDGenerator struct {
+	mu  sync.Mutex
+	ent *ulid.MonotonicEntropy
+}
+
+// NewULIDGenerator creates a ULIDGenerator ready for concurrent use.
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{
+		ent: ulid.Monotonic(crand.Reader, 0),
+	}
+}
+
+// ULID generates a ULID for the current time, panicking on failure - which
+// should only happen if reading crypto/rand fails or the 80-bit monotonic
+// entropy overflows within a single millisecond.
+func (g *ULIDGenerator) ULID() string {
+	s, err := g.ULIDAt(time.Now())
+	if err != nil {
+		panic(WrapErr(err, "ulid generation failed"))
+	}
+	return s
+}
+
+// ULIDErr is ULID, but returns the error instead of panicking.
+func (g *ULIDGenerator) ULIDErr() (string, error) {
+	return g.ULIDAt(time.Now())
+}
+
+// ULIDAt generates a ULID for time t, letting callers produce backdated
+// or future-dated IDs. Only the timestamp part is fixed by t; the entropy
+// part still comes from the generator's random source.
+func (g *ULIDGenerator) ULIDAt(t time.Time) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id, err := ulid.New(ulid.Timestamp(t), g.ent)
+	if err != nil {
+		return "", WrapErr(err, "ulid.New failed")
+	}
+	return id.String(), nil
+}
+
+// defaultULIDGenerator backs the package-level ULID/ULIDErr/ULIDAt helpers.
+var defaultULIDGenerator = NewULIDGenerator()
+
+// ULID generates a ULID using the package's default generator.
+// Panics on failure.
+func ULID() string {
+	return defaultULIDGenerator.ULID()
+}
+
+// ULIDErr is ULID, but returns the error instead of panicking.
+func ULIDErr() (string, error) {
+	return defaultULIDGenerator.ULIDErr()
+}
+
+// ULIDAt generates a ULID for time t using the package's default generator.
+// Panics on failure.
+func ULIDAt(t time.Time) string {
+	s, err := defaultULIDGenerator.ULIDAt(t)
+	if err != nil {
+		panic(WrapErr(err, "ulid generation failed"))
+	}
+	return s
+}
+
+// ParseULIDTime extracts the embedded timestamp from a ULID string, the
+// inverse of the timestamp half of ULID/ULIDAt.
+func ParseULIDTime(s string) (time.Time, error) {
+	id, err := ulid.ParseStrict(s)
+	if err != nil {
+		return time.Time{}, WrapErr(err, "ulid.ParseStrict failed")
+	}
+	return ulid.Time(id.Time()), nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestULIDUnique(t *testing.T) {
+	a := ULID()
+	b := ULID()
+	if a == b {
+		t.Fatalf("two calls to ULID() returned the same value: %q", a)
+	}
+}
+
+func TestULIDConcurrent(t *testing.T) {
+	const n = 200
+	ids := make(chan string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids <- ULID()
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool, n)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ULID generated concurrently: %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestULIDAtRoundTrip(t *testing.T) {
+	at := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	id := ULIDAt(at)
+
+	got, err := ParseULIDTime(id)
+	if err != nil {
+		t.Fatalf("ParseULIDTime(%q) returned error: %v", id, err)
+	}
+	if got.UnixMilli() != at.UnixMilli() {
+		t.Fatalf("ParseULIDTime(%q) = %v, want %v", id, got, at)
+	}
+}
+
+func TestULIDErrNoPanic(t *testing.T) {
+	if _, err := ULIDErr(); err != nil {
+		t.Fatalf("ULIDErr() returned error: %v", err)
+	}
+}
@@ -4,66 +4,23 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash/fnv"
-	"html"
 	"io"
 	"math/rand"
 	"reflect"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/klauspost/compress/zstd"
-	"github.com/oklog/ulid/v2"
 )
 
-type ulidInit struct {
-	t  time.Time
-	en *ulid.MonotonicEntropy
-}
-
-var ulidPool = sync.Pool{
-	New: func() interface{} {
-		t := time.Now()
-		return &ulidInit{
-			t:  t,
-			en: ulid.Monotonic(rand.New(rand.NewSource(time.Now().UnixNano())), 0),
-		}
-	},
-}
-
-// Generates a ULID as described at: "github.com/oklog/ulid/v2"
-// Panics on failure
-func ULID() string {
-	ui, ok := ulidPool.Get().(*ulidInit)
-	if !ok {
-		ulidPool.Put(ui)
-		panic(WrapErr(fmt.Errorf("pool didn't return a MonotonicEntropy")))
-	}
-	ul, err := ulid.New(ulid.Timestamp(ui.t), ui.en)
-	if err != nil {
-		ulidPool.Put(ui)
-		panic(WrapErr(err, "ulid.new failed"))
-	}
-	ulidPool.Put(ui)
-	return ul.String()
-}
-
-// Wraps the given error string with a prefix of
-// Function Name -> [msgs[0] -> msgs[1] -> ... msgs[i]] -> err
-func WrapErr(err error, msgs ...string) error {
-	pc := make([]uintptr, 15)
-	n := runtime.Callers(2, pc)
-	frames := runtime.CallersFrames(pc[:n])
-	frame, _ := frames.Next()
-	src := frame.Function
-	s := strings.Join(append([]string{src}, msgs...), " -> ")
-	return fmt.Errorf("%s -> %s", s, err.Error())
-}
-
 // Random Numbers and String
 // Based on the amazing SO answer: https://stackoverflow.com/a/31832326/2013671
+//
+// These are seeded from the clock and are not safe for security-sensitive
+// use (tokens, passwords, session IDs) - use SecureRandomFromBytes and
+// friends for that.
 const (
 	letterIdxBits = 6                    // 6 bits to represent a letter index
 	letterIdxMask = 1<<letterIdxBits - 1 // All 1-bits, as many as letterIdxBits
@@ -71,6 +28,7 @@ const (
 )
 
 // Generates a random string of length `n` from characters given in `letters`
+// Not safe for security-sensitive use, see SecureRandomFromBytes.
 func RandomFromBytes(n int, letters []byte) string {
 	src := rand.NewSource(time.Now().UnixNano())
 	sb := strings.Builder{}
@@ -92,69 +50,74 @@ func RandomFromBytes(n int, letters []byte) string {
 }
 
 // Generates a random string of length `n` consisting of characters [a-z]
+// Not safe for security-sensitive use, see SecureRandomString.
 func RandomString(n int) string {
 	return RandomFromBytes(n, []byte("abcdefghijklmnopqrstuvwxyz"))
 }
 
 // Generates a string of length `n` consisting only of characters [0-9]
+// Not safe for security-sensitive use, see SecureRandomNumbers.
 func RandomNumbers(n int) string {
 	return RandomFromBytes(n, []byte("0123456789"))
 }
 
+// StringPtr and friends are thin wrappers over Ptr kept for backward
+// compatibility with existing call sites.
+
 func StringPtr(s string) *string {
-	return &s
+	return Ptr(s)
 }
 
 func IntPtr(i int) *int {
-	return &i
+	return Ptr(i)
 }
 
 func Int8Ptr(i int8) *int8 {
-	return &i
+	return Ptr(i)
 }
 
 func Int16Ptr(i int16) *int16 {
-	return &i
+	return Ptr(i)
 }
 
 func Int32Ptr(i int32) *int32 {
-	return &i
+	return Ptr(i)
 }
 
 func Int64Ptr(i int64) *int64 {
-	return &i
+	return Ptr(i)
 }
 
 func UintPtr(u uint) *uint {
-	return &u
+	return Ptr(u)
 }
 
 func Uint8Ptr(u uint8) *uint8 {
-	return &u
+	return Ptr(u)
 }
 
 func Uint16Ptr(u uint16) *uint16 {
-	return &u
+	return Ptr(u)
 }
 
 func Uint32Ptr(u uint32) *uint32 {
-	return &u
+	return Ptr(u)
 }
 
 func Uint64Ptr(u uint64) *uint64 {
-	return &u
+	return Ptr(u)
 }
 
 func Float32Ptr(f float32) *float32 {
-	return &f
+	return Ptr(f)
 }
 
 func Float64Ptr(f float64) *float64 {
-	return &f
+	return Ptr(f)
 }
 
 func BoolPtr(v bool) *bool {
-	return &v
+	return Ptr(v)
 }
 
 // Generates a fnv 64 hash
@@ -191,75 +154,6 @@ func UUID() string {
 	return u.String()
 }
 
-// Obfuscates email IDs, phones, names
-func PartialObfuscate(s string) string {
-	len := len(s)
-	res := ""
-
-	for i, c := range s {
-		if i <= 1 || i >= len-3 || string(c) == "@" {
-			res += string(c)
-			continue
-		}
-		res += "*"
-	}
-	return res
-}
-
-// Sanitizes all string and string pointer types in a struct for XSS
-// Given any struct `s` - accepts reflect.Value(&s)
-func XSSSanitizeStruct(v reflect.Value) {
-	if v.Kind() != reflect.Ptr {
-		return
-	}
-	value := v.Elem()
-	if value.Kind() != reflect.Struct {
-		return
-	}
-
-	dummystr := ""
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
-		if field.Type() == reflect.TypeOf("") {
-			str := field.Interface().(string)
-			field.SetString(html.EscapeString(str))
-		}
-
-		if field.Type() == reflect.TypeOf(&dummystr) {
-			str := field.Interface().(*string)
-			s := html.EscapeString(*str)
-			field.Set(reflect.ValueOf(&s))
-		}
-	}
-}
-
-// Trims all string type and values in string pointer in a struct.
-// Given a struct `s` - accepts reflect.Value(&s)
-func TrimStruct(v reflect.Value) {
-	if v.Kind() != reflect.Ptr {
-		return
-	}
-	value := v.Elem()
-	if value.Kind() != reflect.Struct {
-		return
-	}
-
-	dummystr := ""
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
-		if field.Type() == reflect.TypeOf("") {
-			str := field.Interface().(string)
-			field.SetString(strings.Trim(str, " "))
-		}
-
-		if field.Type() == reflect.TypeOf(&dummystr) {
-			str := field.Interface().(*string)
-			s := strings.Trim(*str, " ")
-			field.Set(reflect.ValueOf(&s))
-		}
-	}
-}
-
 // Get an int64 from any integer parading as an interface
 func GetInt64(v interface{}) (int64, bool) {
 	switch reflect.TypeOf(v).Kind() {